@@ -0,0 +1,372 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// subsystemCollector collects metrics for one WebLogic domainRuntime subtree
+// (JVM, thread pool, JDBC, JMS, ...). Each subsystem is fetched with its own
+// REST round-trip so it can be toggled independently via -collectors.enabled.
+type subsystemCollector interface {
+	// Name identifies the collector in -collectors.enabled (e.g. "jvm").
+	Name() string
+	Describe(ch chan<- *prometheus.Desc)
+	// Collect fetches the subsystem's subtree for serverName and emits metrics.
+	Collect(ctx context.Context, client *http.Client, baseURL, serverName string, ch chan<- prometheus.Metric) error
+}
+
+// getJSON fetches path relative to baseURL, restricting the response to
+// fields via "fields=" and suppressing HATEOAS links via "links=none" so a
+// scrape stays a single small round-trip per subsystem.
+func getJSON(ctx context.Context, client *http.Client, baseURL, path string, fields []string, target interface{}) error {
+	u := strings.TrimRight(baseURL, "/") + path
+	if len(fields) > 0 {
+		q := url.Values{}
+		q.Set("fields", strings.Join(fields, ","))
+		q.Set("links", "none")
+		u += "?" + q.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("building request for %s: %w", path, err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetching %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching %s: unexpected status %s", path, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return nil
+}
+
+// serverRuntimePath builds the path to a named server's runtime subtree.
+func serverRuntimePath(serverName, subtree string) string {
+	return fmt.Sprintf("/management/weblogic/latest/domainRuntime/serverRuntimes/%s/%s", serverName, subtree)
+}
+
+// jvmCollector exposes JVMRuntime heap and GC metrics.
+type jvmCollector struct {
+	heapFreeDesc *prometheus.Desc
+	heapSizeDesc *prometheus.Desc
+	gcCountDesc  *prometheus.Desc
+}
+
+func newJVMCollector() *jvmCollector {
+	return &jvmCollector{
+		heapFreeDesc: prometheus.NewDesc(
+			"weblogic_jvm_heap_free_bytes",
+			"Current free heap memory of the server's JVM in bytes",
+			[]string{"server"}, nil,
+		),
+		heapSizeDesc: prometheus.NewDesc(
+			"weblogic_jvm_heap_size_bytes",
+			"Current total heap memory of the server's JVM in bytes",
+			[]string{"server"}, nil,
+		),
+		gcCountDesc: prometheus.NewDesc(
+			"weblogic_jvm_gc_count_total",
+			"Total number of garbage collections performed by the server's JVM",
+			[]string{"server"}, nil,
+		),
+	}
+}
+
+func (c *jvmCollector) Name() string { return "jvm" }
+
+func (c *jvmCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.heapFreeDesc
+	ch <- c.heapSizeDesc
+	ch <- c.gcCountDesc
+}
+
+func (c *jvmCollector) Collect(ctx context.Context, client *http.Client, baseURL, serverName string, ch chan<- prometheus.Metric) error {
+	var runtime struct {
+		Body struct {
+			HeapFreeCurrent int64 `json:"heapFreeCurrent"`
+			HeapSizeCurrent int64 `json:"heapSizeCurrent"`
+			GcCount         int64 `json:"gcCount"`
+		} `json:"body"`
+	}
+	fields := []string{"heapFreeCurrent", "heapSizeCurrent", "gcCount"}
+	if err := getJSON(ctx, client, baseURL, serverRuntimePath(serverName, "JVMRuntime"), fields, &runtime); err != nil {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.heapFreeDesc, prometheus.GaugeValue, float64(runtime.Body.HeapFreeCurrent), serverName)
+	ch <- prometheus.MustNewConstMetric(c.heapSizeDesc, prometheus.GaugeValue, float64(runtime.Body.HeapSizeCurrent), serverName)
+	ch <- prometheus.MustNewConstMetric(c.gcCountDesc, prometheus.CounterValue, float64(runtime.Body.GcCount), serverName)
+	return nil
+}
+
+// threadPoolCollector exposes the server's self-tuning thread pool metrics.
+type threadPoolCollector struct {
+	executeThreadsDesc  *prometheus.Desc
+	hoggingThreadsDesc  *prometheus.Desc
+	pendingRequestsDesc *prometheus.Desc
+}
+
+func newThreadPoolCollector() *threadPoolCollector {
+	return &threadPoolCollector{
+		executeThreadsDesc: prometheus.NewDesc(
+			"weblogic_threadpool_execute_threads",
+			"Total number of execute threads in the server's thread pool",
+			[]string{"server"}, nil,
+		),
+		hoggingThreadsDesc: prometheus.NewDesc(
+			"weblogic_threadpool_hogging_threads",
+			"Number of execute threads currently hogging the thread pool",
+			[]string{"server"}, nil,
+		),
+		pendingRequestsDesc: prometheus.NewDesc(
+			"weblogic_threadpool_pending_requests",
+			"Number of requests waiting to be processed by the thread pool",
+			[]string{"server"}, nil,
+		),
+	}
+}
+
+func (c *threadPoolCollector) Name() string { return "threadpool" }
+
+func (c *threadPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.executeThreadsDesc
+	ch <- c.hoggingThreadsDesc
+	ch <- c.pendingRequestsDesc
+}
+
+func (c *threadPoolCollector) Collect(ctx context.Context, client *http.Client, baseURL, serverName string, ch chan<- prometheus.Metric) error {
+	var runtime struct {
+		Body struct {
+			ExecuteThreadTotalCount int64 `json:"executeThreadTotalCount"`
+			HoggingThreadCount      int64 `json:"hoggingThreadCount"`
+			PendingUserRequestCount int64 `json:"pendingUserRequestCount"`
+		} `json:"body"`
+	}
+	fields := []string{"executeThreadTotalCount", "hoggingThreadCount", "pendingUserRequestCount"}
+	if err := getJSON(ctx, client, baseURL, serverRuntimePath(serverName, "threadPoolRuntime"), fields, &runtime); err != nil {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.executeThreadsDesc, prometheus.GaugeValue, float64(runtime.Body.ExecuteThreadTotalCount), serverName)
+	ch <- prometheus.MustNewConstMetric(c.hoggingThreadsDesc, prometheus.GaugeValue, float64(runtime.Body.HoggingThreadCount), serverName)
+	ch <- prometheus.MustNewConstMetric(c.pendingRequestsDesc, prometheus.GaugeValue, float64(runtime.Body.PendingUserRequestCount), serverName)
+	return nil
+}
+
+// jdbcCollector exposes per-datasource connection pool metrics.
+type jdbcCollector struct {
+	activeDesc  *prometheus.Desc
+	waitingDesc *prometheus.Desc
+	leakedDesc  *prometheus.Desc
+}
+
+func newJDBCCollector() *jdbcCollector {
+	return &jdbcCollector{
+		activeDesc: prometheus.NewDesc(
+			"weblogic_jdbc_pool_active",
+			"Current number of active connections in the datasource's pool",
+			[]string{"server", "datasource"}, nil,
+		),
+		waitingDesc: prometheus.NewDesc(
+			"weblogic_jdbc_pool_waiters",
+			"Current number of threads waiting for a connection from the datasource's pool",
+			[]string{"server", "datasource"}, nil,
+		),
+		leakedDesc: prometheus.NewDesc(
+			"weblogic_jdbc_pool_leaked_connections_total",
+			"Total number of connections the datasource's pool has reclaimed as leaked",
+			[]string{"server", "datasource"}, nil,
+		),
+	}
+}
+
+func (c *jdbcCollector) Name() string { return "jdbc" }
+
+func (c *jdbcCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.activeDesc
+	ch <- c.waitingDesc
+	ch <- c.leakedDesc
+}
+
+func (c *jdbcCollector) Collect(ctx context.Context, client *http.Client, baseURL, serverName string, ch chan<- prometheus.Metric) error {
+	var service struct {
+		Body struct {
+			JDBCDataSourceRuntimes []struct {
+				Name                             string `json:"name"`
+				ActiveConnectionsCurrentCount    int64  `json:"activeConnectionsCurrentCount"`
+				WaitingForConnectionCurrentCount int64  `json:"waitingForConnectionCurrentCount"`
+				LeakedConnectionCount            int64  `json:"leakedConnectionCount"`
+			} `json:"JDBCDataSourceRuntimes"`
+		} `json:"body"`
+	}
+	fields := []string{
+		"JDBCDataSourceRuntimes.name",
+		"JDBCDataSourceRuntimes.activeConnectionsCurrentCount",
+		"JDBCDataSourceRuntimes.waitingForConnectionCurrentCount",
+		"JDBCDataSourceRuntimes.leakedConnectionCount",
+	}
+	if err := getJSON(ctx, client, baseURL, serverRuntimePath(serverName, "JDBCServiceRuntime"), fields, &service); err != nil {
+		return err
+	}
+
+	for _, ds := range service.Body.JDBCDataSourceRuntimes {
+		ch <- prometheus.MustNewConstMetric(c.activeDesc, prometheus.GaugeValue, float64(ds.ActiveConnectionsCurrentCount), serverName, ds.Name)
+		ch <- prometheus.MustNewConstMetric(c.waitingDesc, prometheus.GaugeValue, float64(ds.WaitingForConnectionCurrentCount), serverName, ds.Name)
+		ch <- prometheus.MustNewConstMetric(c.leakedDesc, prometheus.CounterValue, float64(ds.LeakedConnectionCount), serverName, ds.Name)
+	}
+	return nil
+}
+
+// jmsCollector exposes per-destination message counts across the server's JMS servers.
+type jmsCollector struct {
+	currentDesc *prometheus.Desc
+	pendingDesc *prometheus.Desc
+}
+
+func newJMSCollector() *jmsCollector {
+	return &jmsCollector{
+		currentDesc: prometheus.NewDesc(
+			"weblogic_jms_messages_current",
+			"Current number of messages in the JMS destination",
+			[]string{"server", "destination"}, nil,
+		),
+		pendingDesc: prometheus.NewDesc(
+			"weblogic_jms_messages_pending",
+			"Number of messages in the JMS destination that are pending delivery",
+			[]string{"server", "destination"}, nil,
+		),
+	}
+}
+
+func (c *jmsCollector) Name() string { return "jms" }
+
+func (c *jmsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.currentDesc
+	ch <- c.pendingDesc
+}
+
+func (c *jmsCollector) Collect(ctx context.Context, client *http.Client, baseURL, serverName string, ch chan<- prometheus.Metric) error {
+	var runtime struct {
+		Body struct {
+			JMSServers []struct {
+				Destinations []struct {
+					Name                 string `json:"name"`
+					MessagesCurrentCount int64  `json:"messagesCurrentCount"`
+					MessagesPendingCount int64  `json:"messagesPendingCount"`
+				} `json:"destinations"`
+			} `json:"JMSServers"`
+		} `json:"body"`
+	}
+	fields := []string{"destinations.name", "destinations.messagesCurrentCount", "destinations.messagesPendingCount"}
+	if err := getJSON(ctx, client, baseURL, serverRuntimePath(serverName, "JMSRuntime"), fields, &runtime); err != nil {
+		return err
+	}
+
+	for _, jmsServer := range runtime.Body.JMSServers {
+		for _, dest := range jmsServer.Destinations {
+			ch <- prometheus.MustNewConstMetric(c.currentDesc, prometheus.GaugeValue, float64(dest.MessagesCurrentCount), serverName, dest.Name)
+			ch <- prometheus.MustNewConstMetric(c.pendingDesc, prometheus.GaugeValue, float64(dest.MessagesPendingCount), serverName, dest.Name)
+		}
+	}
+	return nil
+}
+
+// workManagerCollector exposes per-application WorkManager pending request counts.
+type workManagerCollector struct {
+	pendingDesc *prometheus.Desc
+}
+
+func newWorkManagerCollector() *workManagerCollector {
+	return &workManagerCollector{
+		pendingDesc: prometheus.NewDesc(
+			"weblogic_workmanager_pending_requests",
+			"Number of requests queued to the application's WorkManager",
+			[]string{"server", "application", "workmanager"}, nil,
+		),
+	}
+}
+
+func (c *workManagerCollector) Name() string { return "workmgr" }
+
+func (c *workManagerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.pendingDesc
+}
+
+func (c *workManagerCollector) Collect(ctx context.Context, client *http.Client, baseURL, serverName string, ch chan<- prometheus.Metric) error {
+	var runtime struct {
+		Body struct {
+			WorkManagerRuntimes []struct {
+				Name                       string `json:"name"`
+				ApplicationName            string `json:"applicationName"`
+				PendingRequestCurrentCount int64  `json:"pendingRequestCurrentCount"`
+			} `json:"workManagerRuntimes"`
+		} `json:"body"`
+	}
+	fields := []string{
+		"workManagerRuntimes.name",
+		"workManagerRuntimes.applicationName",
+		"workManagerRuntimes.pendingRequestCurrentCount",
+	}
+	if err := getJSON(ctx, client, baseURL, serverRuntimePath(serverName, "workManagerRuntimes"), fields, &runtime); err != nil {
+		return err
+	}
+
+	for _, wm := range runtime.Body.WorkManagerRuntimes {
+		ch <- prometheus.MustNewConstMetric(c.pendingDesc, prometheus.GaugeValue, float64(wm.PendingRequestCurrentCount), serverName, wm.ApplicationName, wm.Name)
+	}
+	return nil
+}
+
+// allSubsystemCollectors returns every subsystem collector keyed by its Name().
+func allSubsystemCollectors() map[string]subsystemCollector {
+	return map[string]subsystemCollector{
+		"jvm":        newJVMCollector(),
+		"threadpool": newThreadPoolCollector(),
+		"jdbc":       newJDBCCollector(),
+		"jms":        newJMSCollector(),
+		"workmgr":    newWorkManagerCollector(),
+	}
+}
+
+// parseEnabledCollectors turns a comma-separated -collectors.enabled value
+// into the matching subsystem collectors, in a stable order for Describe/Collect.
+func parseEnabledCollectors(enabled string) []subsystemCollector {
+	available := allSubsystemCollectors()
+	order := []string{"jvm", "threadpool", "jdbc", "jms", "workmgr"}
+
+	var names []string
+	for _, name := range strings.Split(enabled, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+
+	var selected []subsystemCollector
+	for _, name := range order {
+		if slices.Contains(names, name) {
+			selected = append(selected, available[name])
+		}
+	}
+	return selected
+}
+
+// logCollectorWarning is used when a subsystem collector's fetch fails, so
+// the rest of the scrape can continue instead of aborting entirely.
+func logCollectorWarning(collectorName, serverName string, err error) {
+	log.Printf("collector %s: server %s: %v", collectorName, serverName, err)
+}
@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestParseEnabledCollectors(t *testing.T) {
+	tests := []struct {
+		enabled string
+		want    []string
+	}{
+		{"jvm,threadpool,jdbc,jms,workmgr", []string{"jvm", "threadpool", "jdbc", "jms", "workmgr"}},
+		{"jdbc,jvm", []string{"jvm", "jdbc"}},
+		{" jvm , jdbc ", []string{"jvm", "jdbc"}},
+		{"", nil},
+		{"bogus", nil},
+	}
+	for _, tt := range tests {
+		got := parseEnabledCollectors(tt.enabled)
+		if len(got) != len(tt.want) {
+			t.Errorf("parseEnabledCollectors(%q) = %d collectors, want %d", tt.enabled, len(got), len(tt.want))
+			continue
+		}
+		for i, c := range got {
+			if c.Name() != tt.want[i] {
+				t.Errorf("parseEnabledCollectors(%q)[%d] = %q, want %q", tt.enabled, i, c.Name(), tt.want[i])
+			}
+		}
+	}
+}
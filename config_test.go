@@ -0,0 +1,96 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yml")
+	contents := `
+targets:
+  http://localhost:7001:
+    username: weblogic
+    password: hunter2
+    insecure_skip_verify: true
+    timeout: 15s
+`
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("writing test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+
+	tc, ok := cfg.Lookup("http://localhost:7001")
+	if !ok {
+		t.Fatalf("Lookup(http://localhost:7001) = false, want true")
+	}
+	if tc.Username != "weblogic" || tc.Password != "hunter2" {
+		t.Errorf("Lookup username/password = %q/%q, want weblogic/hunter2", tc.Username, tc.Password)
+	}
+	if !tc.InsecureSkipVerify {
+		t.Errorf("InsecureSkipVerify = false, want true")
+	}
+	if tc.Timeout != 15*time.Second {
+		t.Errorf("Timeout = %v, want 15s", tc.Timeout)
+	}
+
+	if _, ok := cfg.Lookup("http://unknown:7001"); ok {
+		t.Errorf("Lookup(http://unknown:7001) = true, want false")
+	}
+}
+
+func TestLoadConfig_MissingFile(t *testing.T) {
+	if _, err := LoadConfig("/nonexistent/config.yml"); err == nil {
+		t.Fatal("LoadConfig(missing file) = nil error, want an error")
+	}
+}
+
+func TestLoadConfig_InvalidYAML(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yml")
+	if err := os.WriteFile(path, []byte("not: valid: yaml: ["), 0o600); err != nil {
+		t.Fatalf("writing test config: %v", err)
+	}
+	if _, err := LoadConfig(path); err == nil {
+		t.Fatal("LoadConfig(invalid yaml) = nil error, want an error")
+	}
+}
+
+func TestTargetConfig_HTTPClientConfig_FillsDefaults(t *testing.T) {
+	tc := TargetConfig{Username: "u", Password: "p"}
+	got := tc.HTTPClientConfig()
+	want := DefaultHTTPClientConfig()
+	if got.MaxIdleConnsPerHost != want.MaxIdleConnsPerHost {
+		t.Errorf("MaxIdleConnsPerHost = %d, want default %d", got.MaxIdleConnsPerHost, want.MaxIdleConnsPerHost)
+	}
+	if got.ConnectTimeout != want.ConnectTimeout {
+		t.Errorf("ConnectTimeout = %v, want default %v", got.ConnectTimeout, want.ConnectTimeout)
+	}
+	if got.Timeout != want.Timeout {
+		t.Errorf("Timeout = %v, want default %v", got.Timeout, want.Timeout)
+	}
+	if got.Username != "u" || got.Password != "p" {
+		t.Errorf("Username/Password = %q/%q, want u/p", got.Username, got.Password)
+	}
+}
+
+func TestTargetConfig_HTTPClientConfig_OverridesDefaults(t *testing.T) {
+	tc := TargetConfig{MaxIdleConnsPerHost: 7, ConnectTimeout: time.Second, Timeout: 2 * time.Second}
+	got := tc.HTTPClientConfig()
+	if got.MaxIdleConnsPerHost != 7 {
+		t.Errorf("MaxIdleConnsPerHost = %d, want 7", got.MaxIdleConnsPerHost)
+	}
+	if got.ConnectTimeout != time.Second {
+		t.Errorf("ConnectTimeout = %v, want 1s", got.ConnectTimeout)
+	}
+	if got.Timeout != 2*time.Second {
+		t.Errorf("Timeout = %v, want 2s", got.Timeout)
+	}
+}
@@ -1,205 +1,133 @@
 package main
 
 import (
-	"encoding/base64"
-	"encoding/json"
+	"context"
 	"flag"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
-
-	//"strings"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
-	"slices"
-
-	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"github.com/shirou/gopsutil/process"
+	kitlog "github.com/go-kit/log"
+	"github.com/prometheus/exporter-toolkit/web"
 )
 
-// ServerRuntime represents a WebLogic server runtime from the REST API
-type ServerRuntime struct {
-	Name  string `json:"name"`
-	State string `json:"state"`
-}
-
-// ServerRuntimesResponse represents the JSON response from the serverRuntimes endpoint
-type ServerRuntimesResponse struct {
-	Body struct {
-		Items []ServerRuntime `json:"items"`
-	} `json:"body"`
-}
-
-// basicAuthTransport adds basic authentication to HTTP requests
-type basicAuthTransport struct {
-	username string
-	password string
-}
-
-func (t *basicAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	auth := t.username + ":" + t.password
-	encoded := base64.StdEncoding.EncodeToString([]byte(auth))
-	req.Header.Set("Authorization", "Basic "+encoded)
-	return http.DefaultTransport.RoundTrip(req)
-}
-
-// ProcessCollector collects metrics for a specific WebLogic server
-type ProcessCollector struct {
-	adminURL   string
-	username   string
-	password   string
-	serverName string
-	client     *http.Client
-	// Metric descriptors
-	adminUpDesc      *prometheus.Desc
-	serverStatusDesc *prometheus.Desc
-	processArgDesc   *prometheus.Desc
-}
-
-// NewProcessCollector initializes a new ProcessCollector
-func NewProcessCollector(adminURL, username, password, serverName string) *ProcessCollector {
-	client := &http.Client{
-		Transport: &basicAuthTransport{username: username, password: password},
-		Timeout:   10 * time.Second,
-	}
-	return &ProcessCollector{
-		adminURL:   adminURL,
-		username:   username,
-		password:   password,
-		serverName: serverName,
-		client:     client,
-		adminUpDesc: prometheus.NewDesc(
-			"weblogic_admin_server_up",
-			"Whether the WebLogic admin server is up (1 = up, 0 = down)",
-			nil,
-			nil,
-		),
-		serverStatusDesc: prometheus.NewDesc(
-			"weblogic_server_up",
-			"Whether the specified WebLogic server is up (1 = RUNNING, 0 = otherwise)",
-			[]string{"server_name"},
-			nil,
-		),
-		processArgDesc: prometheus.NewDesc(
-			"process_arg",
-			"Command-line arguments of the WebLogic process",
-			[]string{"server_name", "pid", "index", "value"},
-			nil,
-		),
-	}
-}
-
-// Describe sends the metric descriptors to Prometheus
-func (c *ProcessCollector) Describe(ch chan<- *prometheus.Desc) {
-	ch <- c.adminUpDesc
-	ch <- c.serverStatusDesc
-	ch <- c.processArgDesc
-}
-
-// Collect gathers and sends the metrics to Prometheus
-func (c *ProcessCollector) Collect(ch chan<- prometheus.Metric) {
-	// Check admin server status and get server runtime
-	resp, err := c.client.Get(c.adminURL + "/management/weblogic/latest/domainRuntime/serverRuntimes")
-	if err != nil {
-		log.Printf("Failed to connect to admin server: %v", err)
-		ch <- prometheus.MustNewConstMetric(c.adminUpDesc, prometheus.GaugeValue, 0)
-		return
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("Admin server returned status: %s", resp.Status)
-		ch <- prometheus.MustNewConstMetric(c.adminUpDesc, prometheus.GaugeValue, 0)
-		return
-	}
-
-	// Admin server is up
-	ch <- prometheus.MustNewConstMetric(c.adminUpDesc, prometheus.GaugeValue, 1)
+func main() {
+	// Parse command-line arguments
+	var (
+		adminURL    = flag.String("admin-url", "", "URL of the WebLogic admin server for single-target mode (e.g., http://localhost:7001)")
+		username    = flag.String("username", "", "Username for WebLogic admin server (single-target mode)")
+		password    = flag.String("password", "", "Password for WebLogic admin server (single-target mode)")
+		configFile  = flag.String("config.file", "", "Path to a YAML file mapping admin URLs to credentials, for multi-target /probe scraping")
+		port        = flag.Int("port", 9255, "Port for the exporter")
+		collectors  = flag.String("collectors.enabled", "jvm,threadpool,jdbc,jms,workmgr", "Comma-separated list of subsystem collectors to enable (jvm,threadpool,jdbc,jms,workmgr)")
+		concurrency = flag.Int("scrape-concurrency", 8, "Maximum number of managed servers to scrape concurrently")
+
+		caFile             = flag.String("ca-file", "", "PEM CA bundle used to verify the WebLogic admin server's certificate (single-target mode)")
+		certFile           = flag.String("cert-file", "", "PEM client certificate for mTLS to the admin server (single-target mode)")
+		keyFile            = flag.String("key-file", "", "PEM client key for mTLS to the admin server (single-target mode)")
+		insecureSkipVerify = flag.Bool("insecure-skip-verify", false, "Skip TLS certificate verification for the admin server (single-target mode)")
+		tlsServerName      = flag.String("tls-server-name", "", "Override the TLS server name used for certificate verification (single-target mode)")
+		maxIdleConns       = flag.Int("http.max-idle-conns-per-host", 2, "Max idle HTTP connections to keep open per admin server")
+		disableKeepAlives  = flag.Bool("http.disable-keepalives", false, "Disable HTTP keep-alives to the admin server")
+		connectTimeout     = flag.Duration("http.connect-timeout", 5*time.Second, "Timeout for establishing the TCP/TLS connection to the admin server")
+		scrapeTimeout      = flag.Duration("http.timeout", 10*time.Second, "Overall timeout for each REST call to the admin server")
+
+		webConfigFile   = flag.String("web.config.file", "", "Path to an exporter-toolkit web config file enabling TLS and/or basic auth on the exporter's own HTTP server")
+		shutdownTimeout = flag.Duration("web.shutdown-timeout", 30*time.Second, "How long to wait for in-flight scrapes to finish on shutdown before forcing the listener closed")
+
+		redactDenylist  = flag.String("redact.denylist", strings.Join(defaultRedactDenylist, ","), "Comma-separated substrings of -D system property names whose values are redacted before exposition")
+		extraProperties = flag.String("process.extra-properties", "", "Comma-separated -D system property names to expose as weblogic_process_property, in addition to the built-in process info fields; values are redacted per -redact.denylist")
+	)
+	var listenAddresses stringSliceFlag
+	flag.Var(&listenAddresses, "web.listen-address", "Address to listen on for the exporter (repeatable; defaults to :-port)")
+	flag.Parse()
 
-	// Parse server runtimes to find the specified server
-	var runtimes ServerRuntimesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&runtimes); err != nil {
-		log.Printf("Failed to parse server runtimes: %v", err)
-		return
+	if len(listenAddresses) == 0 {
+		listenAddresses = stringSliceFlag{fmt.Sprintf(":%d", *port)}
 	}
 
-	// Check status of the specified server
-	serverFound := false
-	for _, server := range runtimes.Body.Items {
-		if server.Name == c.serverName {
-			serverFound = true
-			value := 0.0
-			if server.State == "RUNNING" {
-				value = 1.0
-			}
-			ch <- prometheus.MustNewConstMetric(
-				c.serverStatusDesc,
-				prometheus.GaugeValue,
-				value,
-				server.Name,
-			)
-			break
+	var cfg *Config
+	if *configFile != "" {
+		var err error
+		cfg, err = LoadConfig(*configFile)
+		if err != nil {
+			log.Fatalf("Failed to load %s: %v", *configFile, err)
 		}
+	} else {
+		cfg = &Config{Targets: map[string]TargetConfig{}}
 	}
 
-	if !serverFound {
-		log.Printf("Server %s not found in domain", c.serverName)
+	if *adminURL != "" {
+		// Single-target CLI mode: fold the flags into the config as the
+		// one target /metrics will probe.
+		if cfg.Targets == nil {
+			cfg.Targets = map[string]TargetConfig{}
+		}
+		cfg.Targets[*adminURL] = TargetConfig{
+			Username:            *username,
+			Password:            *password,
+			CAFile:              *caFile,
+			CertFile:            *certFile,
+			KeyFile:             *keyFile,
+			InsecureSkipVerify:  *insecureSkipVerify,
+			TLSServerName:       *tlsServerName,
+			MaxIdleConnsPerHost: *maxIdleConns,
+			DisableKeepAlives:   *disableKeepAlives,
+			ConnectTimeout:      *connectTimeout,
+			Timeout:             *scrapeTimeout,
+		}
 	}
 
-	// Collect process arguments for the specified server
-	processes, err := process.Processes()
-	if err != nil {
-		log.Printf("Failed to retrieve processes: %v", err)
-		return
+	if *adminURL == "" && len(cfg.Targets) == 0 {
+		fmt.Println("Usage: ./exporter -admin-url <URL> -username <user> -password <pass> [-port <port>] ...")
+		fmt.Println("   or: ./exporter -config.file <config.yml> [-port <port>] ...")
+		os.Exit(1)
 	}
 
-	for _, p := range processes {
-		cmdline, err := p.CmdlineSlice()
-		if err != nil {
-			continue
-		}
-		if slices.Contains(cmdline, "-Dweblogic.Name="+c.serverName) {
-			pid := fmt.Sprintf("%d", p.Pid)
-			for i, arg := range cmdline {
-				ch <- prometheus.MustNewConstMetric(
-					c.processArgDesc,
-					prometheus.GaugeValue,
-					1,
-					c.serverName, pid, fmt.Sprintf("%d", i), arg,
-				)
-			}
-		}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", landingPageHandler)
+	mux.HandleFunc("/healthz", healthzHandler)
+	mux.HandleFunc("/probe", func(w http.ResponseWriter, r *http.Request) {
+		probeHandler(w, r, cfg, *collectors, *concurrency, *redactDenylist, *extraProperties)
+	})
+
+	if *adminURL != "" {
+		// /metrics is a shortcut for probing the single configured target.
+		mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			q.Set("target", *adminURL)
+			r.URL.RawQuery = q.Encode()
+			probeHandler(w, r, cfg, *collectors, *concurrency, *redactDenylist, *extraProperties)
+		})
 	}
-}
 
-func main() {
-	// Parse command-line arguments
-	var (
-		adminURL   = flag.String("admin-url", "", "URL of the WebLogic admin server (e.g., http://localhost:7001)")
-		username   = flag.String("username", "", "Username for WebLogic admin server")
-		password   = flag.String("password", "", "Password for WebLogic admin server")
-		serverName = flag.String("server-name", "", "Name of the WebLogic server to monitor (e.g., AdminServer)")
-		port       = flag.Int("port", 9255, "Port for the exporter")
-	)
-	flag.Parse()
+	// ctx is the base context for every request; cancelling it on SIGINT/SIGTERM
+	// aborts in-flight WLS REST calls immediately instead of waiting them out.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	// Validate required flags
-	if *adminURL == "" || *username == "" || *password == "" || *serverName == "" {
-		fmt.Println("Usage: ./exporter -admin-url <URL> -username <user> -password <pass> -server-name <name> [-port <port>]")
-		os.Exit(1)
+	server := &http.Server{
+		Handler:     mux,
+		BaseContext: func(net.Listener) context.Context { return ctx },
 	}
+	go shutdownGraceful(ctx, server, *shutdownTimeout)
 
-	// Register the collector
-	collector := NewProcessCollector(*adminURL, *username, *password, *serverName)
-	prometheus.MustRegister(collector)
+	logger := kitlog.NewLogfmtLogger(os.Stderr)
+	flagConfig := web.FlagConfig{
+		WebListenAddresses: (*[]string)(&listenAddresses),
+		WebSystemdSocket:   new(bool),
+		WebConfigFile:      webConfigFile,
+	}
 
-	// Start the HTTP server
-	http.Handle("/metrics", promhttp.Handler())
-	addr := fmt.Sprintf(":%d", *port)
-	log.Printf("Starting exporter on %s/metrics for server %s", addr, *serverName)
-	if err := http.ListenAndServe(addr, nil); err != nil {
+	log.Printf("Starting exporter on %v", []string(listenAddresses))
+	if err := web.ListenAndServe(server, &flagConfig, logger); err != nil && err != http.ErrServerClosed {
 		log.Fatalf("Failed to start server: %v", err)
 	}
+	log.Print("Exporter stopped")
 }
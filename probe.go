@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// constMetricsCollector replays a fixed, already-collected set of metrics.
+// It lets the probe handler run a ProcessCollector's Collect exactly once
+// and then serve the result through promhttp.HandlerFor without triggering
+// a second scrape.
+type constMetricsCollector struct {
+	metrics []prometheus.Metric
+}
+
+func (c *constMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, m := range c.metrics {
+		ch <- m.Desc()
+	}
+}
+
+func (c *constMetricsCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range c.metrics {
+		ch <- m
+	}
+}
+
+// probeHandler serves /probe?target=<admin-url>&server=<name>, scraping
+// target with the credentials configured for it and rendering the result
+// through a fresh, per-request registry so one exporter process can front
+// many WebLogic domains.
+func probeHandler(w http.ResponseWriter, r *http.Request, cfg *Config, enabledCollectors string, concurrency int, redactDenylist, extraProperties string) {
+	target := r.URL.Query().Get("target")
+	if target == "" {
+		http.Error(w, "target parameter is required", http.StatusBadRequest)
+		return
+	}
+	serverFilter := r.URL.Query().Get("server")
+
+	creds, ok := cfg.Lookup(target)
+	if !ok {
+		http.Error(w, "no credentials configured for target "+target, http.StatusBadRequest)
+		return
+	}
+
+	client, err := NewHTTPClient(creds.HTTPClientConfig())
+	if err != nil {
+		http.Error(w, "building HTTP client for target "+target+": "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	collector := NewProcessCollector(target, client, enabledCollectors, concurrency, redactDenylist, extraProperties)
+	collector.serverFilter = serverFilter
+
+	start := time.Now()
+	metrics := gatherOnce(r.Context(), collector)
+	duration := time.Since(start)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(&constMetricsCollector{metrics: metrics})
+	registry.MustRegister(collector.scrapeDurations)
+	registry.MustRegister(collector.exporterScrapeDuration)
+
+	probeSuccess := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "weblogic_probe_success",
+		Help: "Whether the probe of this target succeeded",
+	})
+	probeSuccess.Set(boolToFloat(adminServerUp(metrics, collector.adminUpDesc)))
+	registry.MustRegister(probeSuccess)
+
+	probeDuration := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "weblogic_probe_duration_seconds",
+		Help: "Duration of the probe in seconds",
+	})
+	probeDuration.Set(duration.Seconds())
+	registry.MustRegister(probeDuration)
+
+	promhttp.HandlerFor(registry, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+}
+
+// gatherOnce runs collector.Collect a single time and returns every metric
+// it produced. ctx is cancelled if the requesting HTTP connection closes or
+// the exporter is shutting down, which aborts any in-flight WLS requests.
+func gatherOnce(ctx context.Context, collector *ProcessCollector) []prometheus.Metric {
+	ch := make(chan prometheus.Metric, 256)
+	done := make(chan struct{})
+	var metrics []prometheus.Metric
+	go func() {
+		for m := range ch {
+			metrics = append(metrics, m)
+		}
+		close(done)
+	}()
+	collector.Collect(ctx, ch)
+	close(ch)
+	<-done
+	return metrics
+}
+
+// adminServerUp reports the value of the weblogic_admin_server_up metric
+// among metrics, or false if it wasn't emitted.
+func adminServerUp(metrics []prometheus.Metric, adminUpDesc *prometheus.Desc) bool {
+	for _, m := range metrics {
+		if m.Desc() != adminUpDesc {
+			continue
+		}
+		var pb dto.Metric
+		if err := m.Write(&pb); err != nil {
+			log.Printf("failed to read admin-up metric: %v", err)
+			continue
+		}
+		return pb.GetGauge().GetValue() == 1
+	}
+	return false
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestProbeHandler_MissingTarget(t *testing.T) {
+	cfg := &Config{Targets: map[string]TargetConfig{}}
+	req := httptest.NewRequest(http.MethodGet, "/probe", nil)
+	rec := httptest.NewRecorder()
+
+	probeHandler(rec, req, cfg, "", 1, "", "")
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestProbeHandler_UnknownTarget(t *testing.T) {
+	cfg := &Config{Targets: map[string]TargetConfig{}}
+	req := httptest.NewRequest(http.MethodGet, "/probe?target=http://unknown:7001", nil)
+	rec := httptest.NewRecorder()
+
+	probeHandler(rec, req, cfg, "", 1, "", "")
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestProbeHandler_Success(t *testing.T) {
+	wls := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"body":{"items":[{"name":"AdminServer","state":"RUNNING"}]}}`))
+	}))
+	defer wls.Close()
+
+	cfg := &Config{Targets: map[string]TargetConfig{
+		wls.URL: {Username: "weblogic", Password: "welcome1"},
+	}}
+
+	req := httptest.NewRequest(http.MethodGet, "/probe?target="+wls.URL, nil)
+	rec := httptest.NewRecorder()
+
+	probeHandler(rec, req, cfg, "", 1, "", "")
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d, body: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, "weblogic_probe_success 1") {
+		t.Errorf("response missing successful weblogic_probe_success, got:\n%s", body)
+	}
+	if !strings.Contains(body, "weblogic_probe_duration_seconds") {
+		t.Errorf("response missing weblogic_probe_duration_seconds, got:\n%s", body)
+	}
+}
+
+func TestProbeHandler_AdminServerDown(t *testing.T) {
+	wls := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer wls.Close()
+
+	cfg := &Config{Targets: map[string]TargetConfig{
+		wls.URL: {Username: "weblogic", Password: "welcome1"},
+	}}
+
+	req := httptest.NewRequest(http.MethodGet, "/probe?target="+wls.URL, nil)
+	rec := httptest.NewRecorder()
+
+	probeHandler(rec, req, cfg, "", 1, "", "")
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d, body: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), "weblogic_probe_success 0") {
+		t.Errorf("response should report a failed probe, got:\n%s", rec.Body.String())
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import "strings"
+
+// processInfo is the structured, low-cardinality summary of a WebLogic
+// server process's command line, parsed in place of exposing raw argv.
+type processInfo struct {
+	JavaVersion  string
+	WeblogicHome string
+	DomainHome   string
+	JVMVendor    string
+}
+
+// parseProcessInfo extracts the handful of system properties worth
+// reporting as labels from a WebLogic server's argv, defaulting to
+// "unknown" for anything not present.
+func parseProcessInfo(cmdline []string) processInfo {
+	info := processInfo{
+		JavaVersion:  "unknown",
+		WeblogicHome: "unknown",
+		DomainHome:   "unknown",
+		JVMVendor:    "unknown",
+	}
+	for _, arg := range cmdline {
+		key, value, ok := systemProperty(arg)
+		if !ok {
+			continue
+		}
+		switch key {
+		case "weblogic.home":
+			info.WeblogicHome = value
+		case "weblogic.RootDirectory", "domain.home":
+			info.DomainHome = value
+		case "java.version":
+			info.JavaVersion = value
+		case "java.vendor":
+			info.JVMVendor = value
+		}
+	}
+	return info
+}
+
+// systemProperty splits a "-Dkey=value" argv entry into its key and value.
+// ok is false for anything that isn't a -D system property.
+func systemProperty(arg string) (key, value string, ok bool) {
+	if !strings.HasPrefix(arg, "-D") {
+		return "", "", false
+	}
+	kv := strings.SplitN(strings.TrimPrefix(arg, "-D"), "=", 2)
+	if len(kv) != 2 {
+		return kv[0], "", true
+	}
+	return kv[0], kv[1], true
+}
+
+// systemProperties returns every "-Dkey=value" entry in cmdline as a map,
+// keyed by the property name. Used to look up -process.extra-properties,
+// which (unlike the fixed fields parseProcessInfo extracts) may name any
+// system property the operator asks for, so its values must go through a
+// redactor before being exposed as a metric label.
+func systemProperties(cmdline []string) map[string]string {
+	props := make(map[string]string)
+	for _, arg := range cmdline {
+		key, value, ok := systemProperty(arg)
+		if !ok {
+			continue
+		}
+		props[key] = value
+	}
+	return props
+}
+
+// isJVMTuningFlag reports whether arg is a JVM tuning flag (-X... or
+// -XX:...) rather than a WebLogic system property or application argument.
+func isJVMTuningFlag(arg string) bool {
+	return strings.HasPrefix(arg, "-X")
+}
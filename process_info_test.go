@@ -0,0 +1,75 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseProcessInfo(t *testing.T) {
+	cmdline := []string{
+		"java",
+		"-Dweblogic.home=/u01/oracle/wlserver",
+		"-Dweblogic.RootDirectory=/u01/domains/base_domain",
+		"-Dweblogic.Name=AdminServer",
+		"-Xmx2g",
+	}
+	got := parseProcessInfo(cmdline)
+	want := processInfo{
+		JavaVersion:  "unknown",
+		WeblogicHome: "/u01/oracle/wlserver",
+		DomainHome:   "/u01/domains/base_domain",
+		JVMVendor:    "unknown",
+	}
+	if got != want {
+		t.Errorf("parseProcessInfo() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSystemProperties(t *testing.T) {
+	cmdline := []string{
+		"java",
+		"-Dweblogic.management.password=hunter2",
+		"-Dweblogic.Name=AdminServer",
+		"-Xmx2g",
+	}
+	got := systemProperties(cmdline)
+	want := map[string]string{
+		"weblogic.management.password": "hunter2",
+		"weblogic.Name":                "AdminServer",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("systemProperties() = %+v, want %+v", got, want)
+	}
+}
+
+// TestExtraPropertyRedaction proves the real (non-dead) redaction path: an
+// operator-requested extra property whose name matches -redact.denylist gets
+// scrubbed before it would reach a metric label.
+func TestExtraPropertyRedaction(t *testing.T) {
+	cmdline := []string{
+		"java",
+		"-Dweblogic.management.password=hunter2",
+		"-Dweblogic.Name=AdminServer",
+	}
+	props := systemProperties(cmdline)
+	r, _ := newTestRedactor("")
+
+	got := r.redactValue("AdminServer", "123", "weblogic.management.password", props["weblogic.management.password"])
+	if got != "[REDACTED]" {
+		t.Errorf("expected password property to be redacted, got %q", got)
+	}
+}
+
+func TestIsJVMTuningFlag(t *testing.T) {
+	tests := map[string]bool{
+		"-Xmx2g":                  true,
+		"-XX:+UseG1GC":            true,
+		"-Dweblogic.Name=Server1": false,
+		"-jar":                    false,
+	}
+	for arg, want := range tests {
+		if got := isJVMTuningFlag(arg); got != want {
+			t.Errorf("isJVMTuningFlag(%q) = %v, want %v", arg, got, want)
+		}
+	}
+}
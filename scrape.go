@@ -0,0 +1,302 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shirou/gopsutil/process"
+)
+
+// ServerRuntime represents a WebLogic server runtime from the REST API
+type ServerRuntime struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+// ServerRuntimesResponse represents the JSON response from the serverRuntimes endpoint
+type ServerRuntimesResponse struct {
+	Body struct {
+		Items []ServerRuntime `json:"items"`
+	} `json:"body"`
+}
+
+// ProcessCollector collects metrics for every managed server in a WebLogic domain
+type ProcessCollector struct {
+	adminURL    string
+	client      *http.Client
+	subsystems  []subsystemCollector
+	concurrency int
+	// serverFilter, if set, restricts Collect to a single managed server
+	// instead of the whole domain. Used by /probe's "server" parameter.
+	serverFilter string
+	redactor     *redactor
+	// extraProperties names additional -D system properties (beyond the
+	// fixed fields parseProcessInfo extracts) to expose as
+	// weblogic_process_property, each scrubbed through redactor first.
+	// See -process.extra-properties.
+	extraProperties []string
+
+	// Metric descriptors
+	adminUpDesc         *prometheus.Desc
+	serverStatusDesc    *prometheus.Desc
+	processInfoDesc     *prometheus.Desc
+	jvmFlagDesc         *prometheus.Desc
+	processPropertyDesc *prometheus.Desc
+
+	// scrapeDurations tracks, per subsystem collector and server, how long
+	// each per-server REST call took and whether it succeeded.
+	scrapeDurations *prometheus.SummaryVec
+	// exporterScrapeDuration tracks the wall-clock time of a whole domain scrape.
+	exporterScrapeDuration prometheus.Histogram
+}
+
+// NewProcessCollector initializes a new ProcessCollector for the domain
+// fronted by adminURL, using client to make REST calls. enabledCollectors
+// is a comma-separated list of subsystem collector names (see
+// -collectors.enabled); an empty string disables all of them. concurrency
+// bounds how many managed servers are scraped in parallel during a single
+// Collect call. redactDenylist is a comma-separated list of substrings
+// matched against -D system property names to scrub their values (see
+// -redact.denylist); an empty string falls back to defaultRedactDenylist.
+// extraProperties is a comma-separated list of additional -D system
+// property names to expose as weblogic_process_property (see
+// -process.extra-properties); each value is passed through the redactor
+// before exposition.
+func NewProcessCollector(adminURL string, client *http.Client, enabledCollectors string, concurrency int, redactDenylist, extraProperties string) *ProcessCollector {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	var extra []string
+	for _, key := range strings.Split(extraProperties, ",") {
+		key = strings.TrimSpace(key)
+		if key != "" {
+			extra = append(extra, key)
+		}
+	}
+	return &ProcessCollector{
+		adminURL:        adminURL,
+		client:          client,
+		subsystems:      parseEnabledCollectors(enabledCollectors),
+		concurrency:     concurrency,
+		redactor:        newRedactor(redactDenylist, defaultDebugLogger),
+		extraProperties: extra,
+		adminUpDesc: prometheus.NewDesc(
+			"weblogic_admin_server_up",
+			"Whether the WebLogic admin server is up (1 = up, 0 = down)",
+			nil,
+			nil,
+		),
+		serverStatusDesc: prometheus.NewDesc(
+			"weblogic_server_up",
+			"Whether the named WebLogic server is up (1 = RUNNING, 0 = otherwise)",
+			[]string{"server_name"},
+			nil,
+		),
+		processInfoDesc: prometheus.NewDesc(
+			"weblogic_process_info",
+			"Static information about the WebLogic server process, parsed from its command line",
+			[]string{"server", "pid", "java_version", "weblogic_home", "domain_home", "jvm_vendor"},
+			nil,
+		),
+		jvmFlagDesc: prometheus.NewDesc(
+			"weblogic_process_jvm_flag",
+			"Presence of a JVM tuning flag (-X.../-XX:...) on the WebLogic server process",
+			[]string{"server", "pid", "flag"},
+			nil,
+		),
+		processPropertyDesc: prometheus.NewDesc(
+			"weblogic_process_property",
+			"Value of a -D system property named in -process.extra-properties, redacted if its name matches -redact.denylist",
+			[]string{"server", "pid", "key", "value"},
+			nil,
+		),
+		scrapeDurations: prometheus.NewSummaryVec(
+			prometheus.SummaryOpts{
+				Name:       "weblogic_exporter_collector_duration_seconds",
+				Help:       "Duration of each per-server subsystem collector call, by result",
+				Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+			},
+			[]string{"collector", "server", "result"},
+		),
+		exporterScrapeDuration: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "weblogic_exporter_scrape_duration_seconds",
+				Help:    "Duration of a full domain scrape across all managed servers",
+				Buckets: prometheus.DefBuckets,
+			},
+		),
+	}
+}
+
+// Describe sends the metric descriptors to Prometheus
+func (c *ProcessCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.adminUpDesc
+	ch <- c.serverStatusDesc
+	ch <- c.processInfoDesc
+	ch <- c.jvmFlagDesc
+	ch <- c.processPropertyDesc
+	for _, s := range c.subsystems {
+		s.Describe(ch)
+	}
+}
+
+// Collect enumerates every server in the domain and fans out per-server
+// subsystem scrapes across a bounded worker pool. ctx is wired into every
+// outbound WLS request so a cancelled scrape (e.g. on exporter shutdown)
+// aborts in-flight HTTP calls instead of leaking them.
+func (c *ProcessCollector) Collect(ctx context.Context, ch chan<- prometheus.Metric) {
+	start := time.Now()
+	defer func() {
+		c.exporterScrapeDuration.Observe(time.Since(start).Seconds())
+	}()
+
+	// Check admin server status and get server runtimes
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.adminURL+"/management/weblogic/latest/domainRuntime/serverRuntimes", nil)
+	if err != nil {
+		log.Printf("Failed to build request to admin server: %v", err)
+		ch <- prometheus.MustNewConstMetric(c.adminUpDesc, prometheus.GaugeValue, 0)
+		return
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		log.Printf("Failed to connect to admin server: %v", err)
+		ch <- prometheus.MustNewConstMetric(c.adminUpDesc, prometheus.GaugeValue, 0)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("Admin server returned status: %s", resp.Status)
+		ch <- prometheus.MustNewConstMetric(c.adminUpDesc, prometheus.GaugeValue, 0)
+		return
+	}
+
+	// Admin server is up
+	ch <- prometheus.MustNewConstMetric(c.adminUpDesc, prometheus.GaugeValue, 1)
+
+	var runtimes ServerRuntimesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&runtimes); err != nil {
+		log.Printf("Failed to parse server runtimes: %v", err)
+		return
+	}
+
+	servers := runtimes.Body.Items
+	if c.serverFilter != "" {
+		servers = filterServers(servers, c.serverFilter)
+		if len(servers) == 0 {
+			log.Printf("Server %s not found in domain", c.serverFilter)
+		}
+	}
+
+	for _, server := range servers {
+		value := 0.0
+		if server.State == "RUNNING" {
+			value = 1.0
+		}
+		ch <- prometheus.MustNewConstMetric(c.serverStatusDesc, prometheus.GaugeValue, value, server.Name)
+	}
+
+	// Fetched once and shared across servers rather than once per server.
+	processes, err := process.Processes()
+	if err != nil {
+		log.Printf("Failed to retrieve processes: %v", err)
+		processes = nil
+	}
+
+	sem := make(chan struct{}, c.concurrency)
+	var wg sync.WaitGroup
+	for _, server := range servers {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(server ServerRuntime) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			c.collectServer(ctx, server.Name, processes, ch)
+		}(server)
+	}
+	wg.Wait()
+}
+
+// filterServers returns only the server runtimes matching name.
+func filterServers(servers []ServerRuntime, name string) []ServerRuntime {
+	var filtered []ServerRuntime
+	for _, s := range servers {
+		if s.Name == name {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
+// collectServer fetches every enabled subsystem subtree and the process
+// arguments for a single managed server, recording a scrape duration per
+// subsystem collector.
+func (c *ProcessCollector) collectServer(ctx context.Context, serverName string, processes []*process.Process, ch chan<- prometheus.Metric) {
+	for _, s := range c.subsystems {
+		collectStart := time.Now()
+		err := s.Collect(ctx, c.client, c.adminURL, serverName, ch)
+		result := "success"
+		if err != nil {
+			result = "error"
+			logCollectorWarning(s.Name(), serverName, err)
+		}
+		c.scrapeDurations.WithLabelValues(s.Name(), serverName, result).Observe(time.Since(collectStart).Seconds())
+	}
+
+	for _, p := range processes {
+		cmdline, err := p.CmdlineSlice()
+		if err != nil {
+			continue
+		}
+		if !slices.Contains(cmdline, "-Dweblogic.Name="+serverName) {
+			continue
+		}
+		pid := fmt.Sprintf("%d", p.Pid)
+
+		info := parseProcessInfo(cmdline)
+		ch <- prometheus.MustNewConstMetric(
+			c.processInfoDesc,
+			prometheus.GaugeValue,
+			1,
+			serverName, pid,
+			c.redactor.redactValue(serverName, pid, "java.version", info.JavaVersion),
+			c.redactor.redactValue(serverName, pid, "weblogic.home", info.WeblogicHome),
+			c.redactor.redactValue(serverName, pid, "domain.home", info.DomainHome),
+			c.redactor.redactValue(serverName, pid, "java.vendor", info.JVMVendor),
+		)
+
+		seenFlags := make(map[string]bool)
+		for _, arg := range cmdline {
+			if !isJVMTuningFlag(arg) || seenFlags[arg] {
+				continue
+			}
+			seenFlags[arg] = true
+			ch <- prometheus.MustNewConstMetric(c.jvmFlagDesc, prometheus.GaugeValue, 1, serverName, pid, arg)
+		}
+
+		if len(c.extraProperties) > 0 {
+			props := systemProperties(cmdline)
+			for _, key := range c.extraProperties {
+				value, ok := props[key]
+				if !ok {
+					continue
+				}
+				ch <- prometheus.MustNewConstMetric(
+					c.processPropertyDesc,
+					prometheus.GaugeValue,
+					1,
+					serverName, pid, key,
+					c.redactor.redactValue(serverName, pid, key, value),
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package main
+
+import "testing"
+
+func TestFilterServers(t *testing.T) {
+	servers := []ServerRuntime{
+		{Name: "AdminServer", State: "RUNNING"},
+		{Name: "ManagedServer1", State: "RUNNING"},
+		{Name: "ManagedServer2", State: "SHUTDOWN"},
+	}
+
+	got := filterServers(servers, "ManagedServer1")
+	if len(got) != 1 || got[0].Name != "ManagedServer1" {
+		t.Errorf("filterServers(ManagedServer1) = %+v, want a single ManagedServer1", got)
+	}
+
+	if got := filterServers(servers, "NoSuchServer"); len(got) != 0 {
+		t.Errorf("filterServers(NoSuchServer) = %+v, want empty", got)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// stringSliceFlag collects repeated occurrences of a flag.String-style flag
+// (e.g. -web.listen-address) into a slice, the way exporter-toolkit's
+// web.FlagConfig expects its listen addresses.
+type stringSliceFlag []string
+
+func (f *stringSliceFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringSliceFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+const landingPageHTML = `<html>
+<head><title>WebLogic Exporter</title></head>
+<body>
+<h1>WebLogic Exporter</h1>
+<p><a href="/metrics">Metrics</a></p>
+<p><a href="/probe?target=http://localhost:7001&server=AdminServer">Probe</a></p>
+<p><a href="/healthz">Health</a></p>
+</body>
+</html>`
+
+// landingPageHandler serves a minimal index page linking to the endpoints
+// operators actually want, instead of a bare 404 at "/".
+func landingPageHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write([]byte(landingPageHTML))
+}
+
+// healthzHandler always reports healthy: the exporter itself has nothing
+// to be unhealthy about, since WebLogic connectivity is scraped per-target
+// on demand rather than held open as process state.
+func healthzHandler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("OK"))
+}
+
+// shutdownGraceful stops server once ctx is cancelled, giving in-flight
+// scrapes up to timeout to finish before the listener is torn down.
+func shutdownGraceful(ctx context.Context, server *http.Server, timeout time.Duration) {
+	<-ctx.Done()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		server.Close()
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type staticTokenFetcher string
+
+func (f staticTokenFetcher) Token(ctx context.Context) (string, error) {
+	return string(f), nil
+}
+
+// TestNewHTTPClient_TokenAuthWinsOverBasicAuth reproduces the bug where
+// configuring both a TokenFetcher and username/password sent "Authorization:
+// Basic ..." on the wire instead of the bearer token.
+func TestNewHTTPClient_TokenAuthWinsOverBasicAuth(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := DefaultHTTPClientConfig()
+	cfg.Username = "user"
+	cfg.Password = "pass"
+	cfg.TokenFetcher = staticTokenFetcher("my-token")
+
+	client, err := NewHTTPClient(cfg)
+	if err != nil {
+		t.Fatalf("NewHTTPClient: %v", err)
+	}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	resp.Body.Close()
+
+	if want := "Bearer my-token"; gotAuth != want {
+		t.Errorf("Authorization header = %q, want %q", gotAuth, want)
+	}
+}
+
+// TestNewHTTPClient_BasicAuthWithoutTokenFetcher ensures existing basic-auth
+// behavior is unaffected when no TokenFetcher is configured.
+func TestNewHTTPClient_BasicAuthWithoutTokenFetcher(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := DefaultHTTPClientConfig()
+	cfg.Username = "user"
+	cfg.Password = "pass"
+
+	client, err := NewHTTPClient(cfg)
+	if err != nil {
+		t.Fatalf("NewHTTPClient: %v", err)
+	}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	resp.Body.Close()
+
+	if gotAuth == "" || gotAuth[:6] != "Basic " {
+		t.Errorf("Authorization header = %q, want Basic ...", gotAuth)
+	}
+}